@@ -0,0 +1,73 @@
+package verifier
+
+import (
+	"path"
+	"strings"
+)
+
+// matchesHostPattern reports whether hostname matches the passed glob
+// pattern (e.g. "*.google.com"). hostname is trimmed of the trailing dot
+// net.LookupMX results carry (e.g. "alt1.gmail-smtp-in.l.google.com.")
+// before matching, since the glob patterns providers are registered with
+// don't include it.
+func matchesHostPattern(pattern, hostname string) bool {
+	ok, err := path.Match(pattern, strings.TrimSuffix(hostname, "."))
+	return err == nil && ok
+}
+
+// APIVerifier is implemented by provider-specific verification strategies
+// that know how to get a reliable deliverability signal from mailbox
+// providers that actively detect and block generic SMTP probes (Gmail,
+// Yahoo, Outlook, etc). A Verifier consults registered APIVerifiers before
+// falling back to the generic Deliverabler based SMTP probe.
+type APIVerifier interface {
+	// Supports reports whether this APIVerifier knows how to handle the
+	// passed MX hostname (e.g. "alt1.gmail-smtp-in.l.google.com").
+	Supports(mxHostname string) bool
+
+	// Verify performs the provider-specific verification for the passed
+	// Address against the resolved MX hostname, returning a populated
+	// Lookup on success.
+	Verify(address *Address, mxHostname string) (*Lookup, error)
+}
+
+// classifyRCPTResult applies the outcome of an IsDeliverable RCPT probe to
+// l and returns it either way: on success, or on the non-fatal
+// ErrFullInbox response, l is returned with nil error; on any other RCPT
+// rejection or SMTP error, l is still returned - with HostExists already
+// set true by the caller - alongside the classification error, so a
+// provider-specific APIVerifier never reports a rejected recipient as
+// "host doesn't exist" by discarding the Lookup it already built.
+func classifyRCPTResult(l *Lookup, err error) (*Lookup, error) {
+	if err == nil {
+		l.Deliverable = true
+		return l, nil
+	}
+	le := parseRCPTErr(err)
+	if le != nil && le.Message == ErrFullInbox {
+		l.FullInbox = true
+		return l, nil
+	}
+	if le != nil {
+		return l, le
+	}
+	return l, parseSTDErr(err)
+}
+
+// RegisterAPIVerifier registers an APIVerifier that will be consulted,
+// in registration order, ahead of the generic Deliverabler SMTP probe
+// whenever the resolved MX hostname matches one of its supported patterns.
+func (v *Verifier) RegisterAPIVerifier(av APIVerifier) {
+	v.apiVerifiers = append(v.apiVerifiers, av)
+}
+
+// apiVerifierFor returns the first registered APIVerifier that supports
+// the passed MX hostname, or nil if none of them apply.
+func (v *Verifier) apiVerifierFor(mxHostname string) APIVerifier {
+	for _, av := range v.apiVerifiers {
+		if av.Supports(mxHostname) {
+			return av
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package verifier
+
+import "testing"
+
+func TestMatchesHostPattern(t *testing.T) {
+	cases := []struct {
+		pattern, hostname string
+		want              bool
+	}{
+		{"*.google.com", "alt1.gmail-smtp-in.l.google.com", true},
+		{"*.google.com", "alt1.gmail-smtp-in.l.google.com.", true}, // trailing dot from net.LookupMX
+		{"*.google.com", "mail.yahoodns.net", false},
+		{"*.yahoodns.net", "mta7.am0.yahoodns.net", true},
+		{"*.outlook.com", "domain-com.mail.protection.outlook.com", true},
+		{"*.outlook.com", "google.com", false},
+	}
+	for _, c := range cases {
+		if got := matchesHostPattern(c.pattern, c.hostname); got != c.want {
+			t.Errorf("matchesHostPattern(%q, %q) = %v, want %v", c.pattern, c.hostname, got, c.want)
+		}
+	}
+}
+
+func TestClassifyRCPTResultSuccess(t *testing.T) {
+	l := &Lookup{HostExists: true}
+	got, err := classifyRCPTResult(l, nil)
+	if err != nil {
+		t.Fatalf("classifyRCPTResult returned error: %v", err)
+	}
+	if !got.Deliverable {
+		t.Fatal("Deliverable = false, want true on a nil (accepted) RCPT result")
+	}
+	if !got.HostExists {
+		t.Fatal("HostExists was cleared, want the caller's value preserved")
+	}
+}
@@ -0,0 +1,381 @@
+package verifier
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultBulkConcurrency is used when BulkOptions.Concurrency is left at
+// its zero value.
+const defaultBulkConcurrency = 20
+
+// BulkErrorClass categorizes why a BulkResult's Err is set, so callers
+// can decide whether an address is worth retrying later without string
+// matching on the error.
+type BulkErrorClass int
+
+const (
+	// BulkErrorNone means Err is nil.
+	BulkErrorNone BulkErrorClass = iota
+	// BulkErrorParse means the input line wasn't a parseable address.
+	BulkErrorParse
+	// BulkErrorTransient means the SMTP server returned a temporary
+	// failure (4xx, ErrTryAgainLater) that exhausted its retries, or the
+	// context was cancelled before the address was tried.
+	BulkErrorTransient
+	// BulkErrorPermanent means the SMTP server returned a permanent
+	// failure (5xx) or the domain has no MX record.
+	BulkErrorPermanent
+)
+
+// BulkOptions configures VerifyBulk's worker pool and per-domain SMTP
+// connection reuse.
+type BulkOptions struct {
+	// Concurrency bounds how many domains are verified at once. Defaults
+	// to defaultBulkConcurrency.
+	Concurrency int
+	// MaxRetries is how many times a transient SMTP failure is retried,
+	// with RetryBackoff between attempts, before giving up on an address.
+	MaxRetries int
+	// RetryBackoff is the delay before retrying a transient failure.
+	RetryBackoff time.Duration
+}
+
+func (o BulkOptions) concurrency() int {
+	if o.Concurrency > 0 {
+		return o.Concurrency
+	}
+	return defaultBulkConcurrency
+}
+
+func (o BulkOptions) retryBackoff() time.Duration {
+	if o.RetryBackoff > 0 {
+		return o.RetryBackoff
+	}
+	return time.Second * 2
+}
+
+// BulkResult is the outcome of verifying a single input line from
+// VerifyBulk.
+type BulkResult struct {
+	Input      string
+	Lookup     *Lookup
+	Err        error
+	ErrorClass BulkErrorClass
+}
+
+// classifyBulkError maps a raw verification error to a BulkErrorClass.
+func classifyBulkError(err error) BulkErrorClass {
+	if err == nil {
+		return BulkErrorNone
+	}
+	if le := parseRCPTErr(err); le != nil {
+		switch le.Message {
+		case ErrNoSuchHost:
+			return BulkErrorPermanent
+		case ErrTryAgainLater:
+			return BulkErrorTransient
+		}
+	}
+	return BulkErrorPermanent
+}
+
+// domainQueue accumulates pending input lines for a single recipient
+// domain plus the pooled Deliverabler connection that serializes their
+// processing. Exactly one worker ever drains a given domainQueue at a
+// time (see VerifyBulk), so del needs no locking of its own.
+type domainQueue struct {
+	mu     sync.Mutex
+	items  []string
+	active bool
+
+	del *Deliverabler
+}
+
+// VerifyBulk verifies every address read from in concurrently, using a
+// fixed pool of opts.Concurrency workers. Addresses are grouped by
+// recipient domain and a domain's addresses are always processed by a
+// single worker at a time, sharing one Deliverabler connection that's
+// reused across them via RSET instead of being redialed for each
+// address; a transient SMTP failure is retried with backoff before it's
+// reported. A worker only holds a domain for as long as that domain has
+// queued work, then returns to the pool for the next domain that needs
+// one — so the number of distinct domains in in is never bounded by
+// opts.Concurrency. The returned channel is closed once in is closed (or
+// drained) and every in-flight address has reported a BulkResult.
+func (v *Verifier) VerifyBulk(ctx context.Context, in <-chan string, opts BulkOptions) <-chan BulkResult {
+	out := make(chan BulkResult)
+
+	go func() {
+		defer close(out)
+
+		var statesMu sync.Mutex
+		states := make(map[string]*domainQueue)
+		getState := func(domain string) *domainQueue {
+			statesMu.Lock()
+			defer statesMu.Unlock()
+			st, ok := states[domain]
+			if !ok {
+				st = &domainQueue{}
+				states[domain] = st
+			}
+			return st
+		}
+
+		// ready carries domain names that have queued work and no
+		// worker currently assigned to them. It's sized generously so
+		// a burst of newly-seen domains never blocks the producer
+		// behind a full pool of busy workers; ready only ever holds
+		// domain names, not the (unbounded) work itself.
+		ready := make(chan string, 4096)
+
+		var pending sync.WaitGroup // one per queued input line, not yet reported
+		var workers sync.WaitGroup
+		for i := 0; i < opts.concurrency(); i++ {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				for domain := range ready {
+					v.drainDomainQueue(ctx, domain, getState(domain), opts, out, &pending)
+				}
+			}()
+		}
+
+		for line := range in {
+			addr, err := ParseAddress(line)
+			if err != nil {
+				out <- BulkResult{Input: line, Err: err, ErrorClass: BulkErrorParse}
+				continue
+			}
+
+			st := getState(addr.Domain)
+			pending.Add(1)
+			st.mu.Lock()
+			st.items = append(st.items, line)
+			needsDispatch := !st.active
+			if needsDispatch {
+				st.active = true
+			}
+			st.mu.Unlock()
+
+			if needsDispatch {
+				ready <- addr.Domain
+			}
+		}
+
+		pending.Wait() // every queued line has been processed and reported
+		close(ready)
+		workers.Wait()
+
+		statesMu.Lock()
+		for _, st := range states {
+			if st.del != nil {
+				st.del.Close()
+			}
+		}
+		statesMu.Unlock()
+	}()
+
+	return out
+}
+
+// drainDomainQueue processes every line currently queued on st, verifying
+// each against st's pooled Deliverabler connection, then marks st
+// inactive and returns. It never blocks waiting for more work: if a line
+// is enqueued after it returns, VerifyBulk's producer dispatches st to
+// the ready channel again for whichever worker picks it up next.
+func (v *Verifier) drainDomainQueue(ctx context.Context, domain string, st *domainQueue, opts BulkOptions, out chan<- BulkResult, pending *sync.WaitGroup) {
+	for {
+		st.mu.Lock()
+		if len(st.items) == 0 {
+			st.active = false
+			st.mu.Unlock()
+			return
+		}
+		line := st.items[0]
+		st.items = st.items[1:]
+		st.mu.Unlock()
+
+		if ctx.Err() != nil {
+			out <- BulkResult{Input: line, Err: ctx.Err(), ErrorClass: BulkErrorTransient}
+			pending.Done()
+			continue
+		}
+		addr, err := ParseAddress(line)
+		if err != nil {
+			out <- BulkResult{Input: line, Err: err, ErrorClass: BulkErrorParse}
+			pending.Done()
+			continue
+		}
+		lookup, err := v.verifyPooled(addr, &st.del, opts)
+		out <- BulkResult{Input: line, Lookup: lookup, Err: err, ErrorClass: classifyBulkError(err)}
+		pending.Done()
+	}
+}
+
+// verifyPooled verifies addr against *del, dialing it lazily and
+// re-dialing only when the pooled connection has failed, retrying a
+// transient SMTP failure up to opts.MaxRetries times with
+// opts.RetryBackoff between attempts. Every address after the first on
+// the same connection is preceded by an RSET so the MAIL FROM/RCPT TO
+// sequence starts clean without a fresh TCP dial.
+func (v *Verifier) verifyPooled(addr *Address, del **Deliverabler, opts BulkOptions) (*Lookup, error) {
+	l := &Lookup{
+		Address:    *addr,
+		HostExists: true,
+		Disposable: v.disp.IsDisposable(addr.Domain),
+		Gravatar:   v.HasGravatar(addr),
+	}
+
+	for attempt := 0; ; attempt++ {
+		if *del == nil {
+			d, err := NewDeliverabler(addr.Domain, v.dial)
+			if err != nil {
+				le := parseRCPTErr(err)
+				if le != nil && le.Message == ErrNoSuchHost {
+					l.HostExists = false
+				}
+				return nil, parseSTDErr(err)
+			}
+			*del = d
+		} else if err := (*del).Reset(); err != nil {
+			// The pooled connection has gone bad; drop it and redial on
+			// the next loop iteration instead of retrying against it.
+			(*del).Close()
+			*del = nil
+			continue
+		}
+
+		if (*del).HasCatchAll(3) {
+			l.CatchAll = true
+			l.Deliverable = true
+			return l, nil
+		}
+
+		err := (*del).IsDeliverable(addr.Address, 3)
+		if err == nil {
+			l.Deliverable = true
+			return l, nil
+		}
+
+		le := parseRCPTErr(err)
+		if le != nil && le.Message == ErrFullInbox {
+			l.FullInbox = true
+			return l, nil
+		}
+		if le != nil && le.Message == ErrTryAgainLater && attempt < opts.MaxRetries {
+			time.Sleep(opts.retryBackoff())
+			continue
+		}
+		if le != nil {
+			return l, le
+		}
+		return l, parseSTDErr(err)
+	}
+}
+
+// VerifyCSV reads one email address per row (its first column) from r
+// and writes a header plus one verification result row per address to w,
+// running them through VerifyBulk.
+func (v *Verifier) VerifyCSV(ctx context.Context, r io.Reader, w io.Writer, opts BulkOptions) error {
+	cr := csv.NewReader(r)
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return err
+	}
+
+	in := make(chan string, len(rows))
+	for _, row := range rows {
+		if len(row) > 0 && strings.TrimSpace(row[0]) != "" {
+			in <- row[0]
+		}
+	}
+	close(in)
+
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+	if err := cw.Write([]string{"address", "deliverable", "catchAll", "disposable", "gravatar", "error"}); err != nil {
+		return err
+	}
+	for res := range v.VerifyBulk(ctx, in, opts) {
+		record := []string{res.Input}
+		if res.Lookup != nil {
+			record = append(record,
+				boolString(res.Lookup.Deliverable),
+				boolString(res.Lookup.CatchAll),
+				boolString(res.Lookup.Disposable),
+				boolString(res.Lookup.Gravatar),
+				"")
+		} else {
+			record = append(record, "", "", "", "", errString(res.Err))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return cw.Error()
+}
+
+// VerifyJSONL reads one email address per newline-delimited line from r
+// and writes one JSON-encoded BulkResult per line to w, running them
+// through VerifyBulk.
+func (v *Verifier) VerifyJSONL(ctx context.Context, r io.Reader, w io.Writer, opts BulkOptions) error {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	in := make(chan string, len(lines))
+	for _, line := range lines {
+		in <- line
+	}
+	close(in)
+
+	enc := json.NewEncoder(w)
+	for res := range v.VerifyBulk(ctx, in, opts) {
+		if err := enc.Encode(jsonBulkResult(res)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonBulkResult adapts a BulkResult for JSON encoding, since error
+// values don't marshal to anything useful on their own.
+func jsonBulkResult(res BulkResult) map[string]interface{} {
+	m := map[string]interface{}{
+		"input":      res.Input,
+		"lookup":     res.Lookup,
+		"errorClass": res.ErrorClass,
+	}
+	if res.Err != nil {
+		m["error"] = res.Err.Error()
+	}
+	return m
+}
+
+func boolString(b bool) string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func errString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,67 @@
+package verifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestClassifyBulkErrorNil(t *testing.T) {
+	if got := classifyBulkError(nil); got != BulkErrorNone {
+		t.Fatalf("classifyBulkError(nil) = %v, want BulkErrorNone", got)
+	}
+}
+
+func TestClassifyBulkErrorUnclassified(t *testing.T) {
+	// An error parseRCPTErr doesn't recognize still has to be reported as
+	// something rather than silently resolving to BulkErrorNone.
+	if got := classifyBulkError(errors.New("boom")); got != BulkErrorPermanent {
+		t.Fatalf("classifyBulkError(unclassified) = %v, want BulkErrorPermanent", got)
+	}
+}
+
+// TestDrainDomainQueueContextCancelled verifies that a cancelled context
+// is reported as a transient failure for every already-queued line
+// instead of attempting the (now pointless) SMTP dial, and that the
+// queue is left inactive for the next dispatch.
+func TestDrainDomainQueueContextCancelled(t *testing.T) {
+	v := &Verifier{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	st := &domainQueue{items: []string{"someone@example.com"}, active: true}
+	out := make(chan BulkResult, 1)
+	var pending sync.WaitGroup
+	pending.Add(1)
+
+	v.drainDomainQueue(ctx, "example.com", st, BulkOptions{}, out, &pending)
+
+	res := <-out
+	if res.ErrorClass != BulkErrorTransient {
+		t.Fatalf("ErrorClass = %v, want BulkErrorTransient", res.ErrorClass)
+	}
+	if st.active {
+		t.Fatal("domainQueue left active after draining")
+	}
+}
+
+// TestDrainDomainQueueParseError verifies an unparseable queued line is
+// reported as a parse failure without attempting a dial.
+func TestDrainDomainQueueParseError(t *testing.T) {
+	v := &Verifier{}
+	st := &domainQueue{items: []string{"not-an-email-at-all"}, active: true}
+	out := make(chan BulkResult, 1)
+	var pending sync.WaitGroup
+	pending.Add(1)
+
+	v.drainDomainQueue(context.Background(), "example.com", st, BulkOptions{}, out, &pending)
+
+	res := <-out
+	if res.ErrorClass != BulkErrorParse {
+		t.Fatalf("ErrorClass = %v, want BulkErrorParse", res.ErrorClass)
+	}
+	if st.active {
+		t.Fatal("domainQueue left active after draining")
+	}
+}
@@ -0,0 +1,138 @@
+package verifier
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"net/smtp"
+)
+
+// smtpPort is the standard SMTP port; none of the hosts this package
+// dials listen anywhere else.
+const smtpPort = "25"
+
+// Deliverabler wraps a single SMTP connection to an MX host and exposes
+// the CatchAll/RCPT probes Verifier and the APIVerifiers build their
+// Lookup fields from. A Deliverabler can be reused across multiple
+// addresses on the same domain via Reset instead of redialing for each
+// one (see bulk.go's verifyPooled).
+type Deliverabler struct {
+	client   *smtp.Client
+	domain   string
+	hostname string
+}
+
+// NewDeliverabler resolves domain's MX records and opens an SMTP
+// connection to the preferred host, dialing through dial so proxy
+// selection, local address pinning and per-domain rate limiting apply
+// exactly as they do for every other SMTP connection the Verifier opens.
+func NewDeliverabler(domain string, dial *DialConfig) (*Deliverabler, error) {
+	mxHost, err := lookupMXHost(domain)
+	if err != nil {
+		return nil, err
+	}
+	if mxHost == "" {
+		return nil, &net.DNSError{Err: "no such host", Name: domain, IsNotFound: true}
+	}
+	d, err := dialDeliverabler(domain, mxHost, dial)
+	if err != nil {
+		return nil, err
+	}
+	d.domain = domain
+	return d, nil
+}
+
+// NewDeliverablerFromHost opens an SMTP connection directly to mxHost,
+// dialing through dial like NewDeliverabler does, except that proxy/local
+// address selection and the dial rate limit are keyed by mxHost rather
+// than by a recipient domain. The returned Deliverabler carries no
+// recipient domain, so HasCatchAll is not meaningful on it. It's used by
+// APIVerifiers that have already resolved the MX host themselves and
+// don't need a second lookup.
+func NewDeliverablerFromHost(mxHost string, dial *DialConfig) (*Deliverabler, error) {
+	return dialDeliverabler(mxHost, mxHost, dial)
+}
+
+// dialDeliverabler dials mxHost on smtpPort through dial.Dialer, keyed by
+// rateLimitKey for proxy/local-addr selection and per-domain rate
+// limiting, then completes the SMTP greeting.
+func dialDeliverabler(rateLimitKey, mxHost string, dial *DialConfig) (*Deliverabler, error) {
+	dialer, err := dial.Dialer(rateLimitKey)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := dialer.Dial("tcp", net.JoinHostPort(mxHost, smtpPort))
+	if err != nil {
+		return nil, err
+	}
+	client, err := smtp.NewClient(conn, mxHost)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if err := client.Hello(dial.Hostname); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &Deliverabler{client: client, hostname: dial.Hostname}, nil
+}
+
+// probeAddress resets the SMTP transaction and runs a MAIL FROM/RCPT TO
+// sequence against address. The RSET is required before every probe past
+// the first one on a connection, since neither MAIL nor RCPT on their own
+// close out the prior transaction.
+func (d *Deliverabler) probeAddress(address string) error {
+	if err := d.client.Reset(); err != nil {
+		return err
+	}
+	if err := d.client.Mail("verify@" + d.hostname); err != nil {
+		return err
+	}
+	return d.client.Rcpt(address)
+}
+
+// HasCatchAll reports whether domain's server accepts mail for a
+// virtually-guaranteed-nonexistent address, which means it can't be
+// trusted to reject any other bad recipient either. retries bounds how
+// many times a non-classified (i.e. not yet a definitive accept/reject)
+// probe failure is retried before giving up and reporting no catch-all.
+func (d *Deliverabler) HasCatchAll(retries int) bool {
+	address := fmt.Sprintf("%d-trumail-catchall-probe@%s", rand.Int63(), d.domain)
+	for attempt := 0; attempt <= retries; attempt++ {
+		err := d.probeAddress(address)
+		if err == nil {
+			return true
+		}
+		if parseRCPTErr(err) != nil {
+			return false // a definitive rejection, not worth retrying
+		}
+	}
+	return false
+}
+
+// IsDeliverable performs the RCPT TO probe for address, retrying up to
+// retries times on a probe failure that parseRCPTErr doesn't classify as
+// a definitive RCPT rejection.
+func (d *Deliverabler) IsDeliverable(address string, retries int) error {
+	var err error
+	for attempt := 0; attempt <= retries; attempt++ {
+		if err = d.probeAddress(address); err == nil {
+			return nil
+		}
+		if parseRCPTErr(err) != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// Reset clears the current SMTP transaction so the connection can be
+// reused for another address on the same domain without a fresh dial.
+func (d *Deliverabler) Reset() error {
+	return d.client.Reset()
+}
+
+// Close terminates the SMTP connection.
+func (d *Deliverabler) Close() error {
+	return d.client.Quit()
+}
@@ -0,0 +1,219 @@
+package verifier
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// DialStrategy selects how DialConfig picks a proxy/local address for a
+// given verification.
+type DialStrategy int
+
+const (
+	// DialStrategyRoundRobin cycles through the configured proxies/local
+	// addresses in order.
+	DialStrategyRoundRobin DialStrategy = iota
+	// DialStrategyRandom picks a proxy/local address at random per dial.
+	DialStrategyRandom
+	// DialStrategyStickyDomain always picks the same proxy/local address
+	// for a given recipient domain, so repeated lookups against the same
+	// mailbox provider look consistent.
+	DialStrategyStickyDomain
+)
+
+// Dialer is the minimal interface Deliverabler needs to open the SMTP
+// connection, satisfied by both *net.Dialer and the proxy dialers
+// DialConfig builds from WithProxies.
+type Dialer interface {
+	Dial(network, address string) (net.Conn, error)
+}
+
+// DialConfig holds everything that influences how Deliverabler dials an
+// MX server: which local address to dial from, which proxy (if any) to
+// dial through, and how aggressively to throttle dials per recipient
+// domain. It replaces the bare hostname/sourceAddr fields Verifier used
+// to carry directly.
+type DialConfig struct {
+	Hostname string
+
+	mu         sync.Mutex
+	localAddrs []net.IP
+	proxies    []string
+	strategy   DialStrategy
+	rrIndex    int
+
+	limiter *dialRateLimiter
+}
+
+// NewDialConfig returns a DialConfig that identifies itself as hostname
+// and dials directly (no proxy, no local address pinning) using
+// round-robin selection once proxies/local addresses are added.
+func NewDialConfig(hostname string) *DialConfig {
+	return &DialConfig{Hostname: hostname, strategy: DialStrategyRoundRobin}
+}
+
+// setLocalAddrs replaces the pool of local addresses DialConfig picks
+// from when dialing.
+func (d *DialConfig) setLocalAddrs(addrs []net.IP) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.localAddrs = addrs
+}
+
+// setProxies replaces the pool of proxy URLs DialConfig picks from when
+// dialing. Each URL is either "socks5://[user:pass@]host:port" or
+// "http(s)://[user:pass@]host:port".
+func (d *DialConfig) setProxies(proxies []string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.proxies = proxies
+}
+
+// setStrategy changes how DialConfig picks among multiple proxies/local
+// addresses.
+func (d *DialConfig) setStrategy(strategy DialStrategy) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.strategy = strategy
+}
+
+// setRateLimit configures the per-domain dial throttle. A non-positive
+// perDomain disables the limiter entirely, so no per-domain throttle is
+// applied to dials.
+func (d *DialConfig) setRateLimit(perDomain int, window time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if perDomain <= 0 {
+		d.limiter = nil
+		return
+	}
+	d.limiter = newDialRateLimiter(perDomain, window)
+}
+
+// pick selects an index into a pool of size n for the passed recipient
+// domain, honoring the configured DialStrategy. It returns -1 if n is 0.
+func (d *DialConfig) pick(domain string, n int) int {
+	if n == 0 {
+		return -1
+	}
+	switch d.strategy {
+	case DialStrategyRandom:
+		return rand.Intn(n)
+	case DialStrategyStickyDomain:
+		return int(fnv32(domain) % uint32(n))
+	default: // DialStrategyRoundRobin
+		d.rrIndex++
+		return (d.rrIndex - 1) % n
+	}
+}
+
+// fnv32 is a tiny FNV-1a hash used to stickily map a domain onto a pool
+// index without pulling in hash/fnv for a single call site.
+func fnv32(s string) uint32 {
+	const offset32 = 2166136261
+	const prime32 = 16777619
+	h := uint32(offset32)
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(s[i])
+		h *= prime32
+	}
+	return h
+}
+
+// Dialer builds the net.Dialer (optionally wrapped in a proxy dialer) to
+// use for a connection to the passed recipient domain, applying the
+// configured local address pinning, proxy selection and per-domain rate
+// limit.
+func (d *DialConfig) Dialer(domain string) (Dialer, error) {
+	d.mu.Lock()
+	localAddrs, proxies, limiter := d.localAddrs, d.proxies, d.limiter
+	// Both picks share one round-robin step per dial, so each pool is
+	// cycled in order even when both are configured.
+	rr := d.rrIndex
+	localIdx := d.pick(domain, len(localAddrs))
+	if len(proxies) > 0 {
+		d.rrIndex = rr
+	}
+	proxyIdx := d.pick(domain, len(proxies))
+	d.mu.Unlock()
+
+	if limiter != nil {
+		limiter.wait(domain)
+	}
+
+	base := &net.Dialer{Timeout: time.Second * 30}
+	if localIdx >= 0 {
+		base.LocalAddr = &net.TCPAddr{IP: localAddrs[localIdx]}
+	}
+	if proxyIdx < 0 {
+		return base, nil
+	}
+	return parseProxyDialer(proxies[proxyIdx], base)
+}
+
+// parseProxyDialer builds a Dialer that tunnels through the proxy
+// described by rawURL, using forward as the underlying direct dialer.
+func parseProxyDialer(rawURL string, forward *net.Dialer) (Dialer, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy URL %q: %w", rawURL, err)
+	}
+	switch u.Scheme {
+	case "socks5":
+		return proxy.FromURL(u, forward)
+	case "http", "https":
+		return newHTTPProxyDialer(u, forward), nil
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %q", u.Scheme)
+	}
+}
+
+// dialRateLimiter self-throttles dials to at most perDomain per window,
+// per recipient domain, blocking callers past the limit instead of
+// rejecting them outright so bulk verification backs off smoothly
+// instead of tripping greylisting.
+type dialRateLimiter struct {
+	mu        sync.Mutex
+	perDomain int
+	window    time.Duration
+	hits      map[string][]time.Time
+}
+
+func newDialRateLimiter(perDomain int, window time.Duration) *dialRateLimiter {
+	return &dialRateLimiter{perDomain: perDomain, window: window, hits: make(map[string][]time.Time)}
+}
+
+// wait blocks until a dial slot for domain is available, then reserves
+// it. A non-positive perDomain would otherwise mean len(live) < perDomain
+// never holds, so live[0] would be indexed unconditionally below; treat
+// it as no limit instead.
+func (r *dialRateLimiter) wait(domain string) {
+	if r.perDomain <= 0 {
+		return
+	}
+	for {
+		r.mu.Lock()
+		now := time.Now()
+		var live []time.Time
+		for _, t := range r.hits[domain] {
+			if now.Sub(t) < r.window {
+				live = append(live, t)
+			}
+		}
+		if len(live) < r.perDomain {
+			r.hits[domain] = append(live, now)
+			r.mu.Unlock()
+			return
+		}
+		r.hits[domain] = live
+		wait := r.window - now.Sub(live[0])
+		r.mu.Unlock()
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,134 @@
+package verifier
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestDialConfigPickEmptyPool(t *testing.T) {
+	d := NewDialConfig("test")
+	if got := d.pick("example.com", 0); got != -1 {
+		t.Fatalf("pick(_, 0) = %d, want -1", got)
+	}
+}
+
+func TestDialConfigPickRoundRobin(t *testing.T) {
+	d := NewDialConfig("test")
+	d.setStrategy(DialStrategyRoundRobin)
+	want := []int{0, 1, 2, 0}
+	for i, w := range want {
+		if got := d.pick("a.com", 3); got != w {
+			t.Fatalf("pick #%d = %d, want %d", i, got, w)
+		}
+	}
+}
+
+func TestDialConfigPickStickyDomain(t *testing.T) {
+	d := NewDialConfig("test")
+	d.setStrategy(DialStrategyStickyDomain)
+	first := d.pick("example.com", 5)
+	if first < 0 || first >= 5 {
+		t.Fatalf("pick = %d, want in [0,5)", first)
+	}
+	for i := 0; i < 10; i++ {
+		if got := d.pick("example.com", 5); got != first {
+			t.Fatalf("sticky pick changed on call %d: got %d, want %d", i, got, first)
+		}
+	}
+	// A different domain is still free to land on a different index.
+	_ = d.pick("other.com", 5)
+}
+
+func TestDialConfigPickRandomInRange(t *testing.T) {
+	d := NewDialConfig("test")
+	d.setStrategy(DialStrategyRandom)
+	for i := 0; i < 50; i++ {
+		if got := d.pick("example.com", 4); got < 0 || got >= 4 {
+			t.Fatalf("pick #%d = %d, want in [0,4)", i, got)
+		}
+	}
+}
+
+// TestDialRateLimiterWaitZeroPerDomain is a regression test for a
+// WithDialRateLimit(0, window) call panicking on its first dial with
+// "index out of range [0] with length 0", since live never reaches
+// perDomain and wait indexed live[0] unconditionally.
+func TestDialRateLimiterWaitZeroPerDomain(t *testing.T) {
+	r := newDialRateLimiter(0, time.Second)
+
+	done := make(chan struct{})
+	go func() {
+		r.wait("example.com")
+		r.wait("example.com") // a second call must not block either
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("wait blocked instead of treating perDomain <= 0 as unlimited")
+	}
+}
+
+func TestDialRateLimiterWaitThrottlesWithinDomain(t *testing.T) {
+	window := 40 * time.Millisecond
+	r := newDialRateLimiter(1, window)
+
+	start := time.Now()
+	r.wait("example.com") // admitted immediately
+	r.wait("example.com") // must wait out the rest of the window
+	if elapsed := time.Since(start); elapsed < window {
+		t.Fatalf("second wait returned after %v, want at least %v", elapsed, window)
+	}
+}
+
+// TestDialerAppliesLocalAddr is a regression test for Dialer being
+// configured (via WithLocalAddrs) but never consulted by Deliverabler:
+// it asserts the *net.Dialer Dialer returns actually carries the
+// configured local address.
+func TestDialerAppliesLocalAddr(t *testing.T) {
+	d := NewDialConfig("test")
+	addr := net.ParseIP("127.0.0.2")
+	d.setLocalAddrs([]net.IP{addr})
+
+	dialer, err := d.Dialer("example.com")
+	if err != nil {
+		t.Fatalf("Dialer returned error: %v", err)
+	}
+	nd, ok := dialer.(*net.Dialer)
+	if !ok {
+		t.Fatalf("Dialer returned %T, want *net.Dialer", dialer)
+	}
+	tcpAddr, ok := nd.LocalAddr.(*net.TCPAddr)
+	if !ok || !tcpAddr.IP.Equal(addr) {
+		t.Fatalf("LocalAddr = %v, want IP %v", nd.LocalAddr, addr)
+	}
+}
+
+// TestDialerAppliesProxy is a regression test for the same gap as
+// TestDialerAppliesLocalAddr: it asserts a configured proxy causes Dialer
+// to return a proxy-wrapped Dialer instead of the bare *net.Dialer.
+func TestDialerAppliesProxy(t *testing.T) {
+	d := NewDialConfig("test")
+	d.setProxies([]string{"http://proxy.example.com:8080"})
+
+	dialer, err := d.Dialer("example.com")
+	if err != nil {
+		t.Fatalf("Dialer returned error: %v", err)
+	}
+	if _, ok := dialer.(*net.Dialer); ok {
+		t.Fatal("Dialer returned the bare *net.Dialer, want a proxy-wrapped Dialer")
+	}
+}
+
+func TestDialRateLimiterWaitIsPerDomain(t *testing.T) {
+	r := newDialRateLimiter(1, time.Hour)
+
+	start := time.Now()
+	r.wait("a.com")
+	r.wait("b.com") // a different domain's slot, shouldn't wait on a.com's
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("wait on an unrelated domain took %v, want near-instant", elapsed)
+	}
+}
@@ -0,0 +1,202 @@
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sdwolfe32/httpclient"
+)
+
+// splitLines splits a newline-delimited feed into trimmed, non-empty
+// lines.
+func splitLines(raw string) []string {
+	lines := strings.Split(raw, "\n")
+	trimmed := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if l := strings.TrimSpace(line); l != "" {
+			trimmed = append(trimmed, l)
+		}
+	}
+	return trimmed
+}
+
+// defaultDisposableFeedURL is the community-maintained disposable email
+// domain feed used by the default DisposableSource.
+const defaultDisposableFeedURL = "https://raw.githubusercontent.com/disposable-email-domains/disposable-email-domains/master/disposable_email_blocklist.conf"
+
+// DisposableSource determines whether a domain belongs to a disposable
+// email provider and knows how to refresh its underlying data set.
+type DisposableSource interface {
+	// IsDisposable reports whether domain is a known disposable email
+	// domain.
+	IsDisposable(domain string) bool
+
+	// Refresh reloads the domain set. Implementations that have nothing
+	// to refresh (a static or user-supplied list) simply return nil.
+	Refresh(ctx context.Context) error
+}
+
+// DisposableMutator is implemented by DisposableSources that support
+// runtime addition and removal of individual domains, such as
+// StaticDisposableSource and MapDisposableSource.
+type DisposableMutator interface {
+	Add(domains ...string)
+	Remove(domains ...string)
+}
+
+// StaticDisposableSource is a DisposableSource backed by a fixed
+// in-memory list holding exactly the domains passed to
+// NewStaticDisposableSource; it carries no domains of its own. It never
+// changes on Refresh, but does support runtime Add/Remove via
+// DisposableMutator.
+type StaticDisposableSource struct {
+	mu      sync.RWMutex
+	domains map[string]bool
+}
+
+// NewStaticDisposableSource returns a StaticDisposableSource seeded with
+// the passed domains.
+func NewStaticDisposableSource(domains []string) *StaticDisposableSource {
+	s := &StaticDisposableSource{domains: make(map[string]bool, len(domains))}
+	s.Add(domains...)
+	return s
+}
+
+// IsDisposable reports whether domain is in the static list.
+func (s *StaticDisposableSource) IsDisposable(domain string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.domains[domain]
+}
+
+// Refresh is a no-op for a static list.
+func (s *StaticDisposableSource) Refresh(ctx context.Context) error { return nil }
+
+// Add adds domains to the static list.
+func (s *StaticDisposableSource) Add(domains ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range domains {
+		s.domains[d] = true
+	}
+}
+
+// Remove removes domains from the static list.
+func (s *StaticDisposableSource) Remove(domains ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range domains {
+		delete(s.domains, d)
+	}
+}
+
+// MapDisposableSource is a DisposableSource backed entirely by a
+// user-supplied map, for callers who maintain their own disposable list.
+type MapDisposableSource struct {
+	mu      sync.RWMutex
+	domains map[string]bool
+}
+
+// NewMapDisposableSource returns a MapDisposableSource seeded with the
+// passed domains map. The map is copied, so later mutation of the
+// original has no effect.
+func NewMapDisposableSource(domains map[string]bool) *MapDisposableSource {
+	s := &MapDisposableSource{domains: make(map[string]bool, len(domains))}
+	for d, disposable := range domains {
+		if disposable {
+			s.domains[d] = true
+		}
+	}
+	return s
+}
+
+// IsDisposable reports whether domain is marked disposable in the map.
+func (s *MapDisposableSource) IsDisposable(domain string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.domains[domain]
+}
+
+// Refresh is a no-op for a user-supplied map.
+func (s *MapDisposableSource) Refresh(ctx context.Context) error { return nil }
+
+// Add adds domains to the map.
+func (s *MapDisposableSource) Add(domains ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range domains {
+		s.domains[d] = true
+	}
+}
+
+// Remove removes domains from the map.
+func (s *MapDisposableSource) Remove(domains ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range domains {
+		delete(s.domains, d)
+	}
+}
+
+// RemoteDisposableSource is a DisposableSource backed by a remote
+// newline-delimited or JSON array feed, such as the community-maintained
+// disposable-email-domains lists. Refresh atomically swaps in the newly
+// fetched domain set on success and leaves the previous set in place on
+// failure, so a transient fetch error never empties the list.
+type RemoteDisposableSource struct {
+	client *httpclient.Client
+	url    string
+
+	mu      sync.RWMutex
+	domains map[string]bool
+}
+
+// NewRemoteDisposableSource returns a RemoteDisposableSource that fetches
+// its domain list from url using client. Callers should call Refresh once
+// before first use to populate the initial list.
+func NewRemoteDisposableSource(client *httpclient.Client, url string) *RemoteDisposableSource {
+	return &RemoteDisposableSource{client: client, url: url, domains: make(map[string]bool)}
+}
+
+// IsDisposable reports whether domain is in the most recently fetched
+// list.
+func (s *RemoteDisposableSource) IsDisposable(domain string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.domains[domain]
+}
+
+// Refresh fetches the feed at s.url and, on success, atomically swaps it
+// in as the active domain set. The feed may be a JSON array of domains or
+// a newline-delimited list; either is parsed into the same set.
+func (s *RemoteDisposableSource) Refresh(ctx context.Context) error {
+	raw, err := s.client.Get(s.url)
+	if err != nil {
+		return fmt.Errorf("failed retrieving disposable domain feed: %w", err)
+	}
+
+	domains := make(map[string]bool)
+	var jsonList []string
+	if err := json.Unmarshal(raw, &jsonList); err == nil {
+		for _, d := range jsonList {
+			domains[d] = true
+		}
+	} else {
+		for _, line := range splitLines(string(raw)) {
+			if line != "" {
+				domains[line] = true
+			}
+		}
+	}
+	if len(domains) == 0 {
+		return fmt.Errorf("disposable domain feed at %s returned no domains", s.url)
+	}
+
+	s.mu.Lock()
+	s.domains = domains
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,77 @@
+package verifier
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sdwolfe32/httpclient"
+)
+
+func newTestRemoteDisposableSource(t *testing.T, body string, status int) (*RemoteDisposableSource, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	client := httpclient.New(time.Second*5, nil)
+	return NewRemoteDisposableSource(client, srv.URL), srv
+}
+
+func TestRemoteDisposableSourceRefreshParsesJSON(t *testing.T) {
+	s, srv := newTestRemoteDisposableSource(t, `["mailinator.com", "trashmail.com"]`, http.StatusOK)
+	defer srv.Close()
+
+	if err := s.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if !s.IsDisposable("mailinator.com") || !s.IsDisposable("trashmail.com") {
+		t.Fatal("Refresh didn't parse the JSON array feed")
+	}
+	if s.IsDisposable("gmail.com") {
+		t.Fatal("IsDisposable(gmail.com) = true, want false")
+	}
+}
+
+func TestRemoteDisposableSourceRefreshParsesNewlines(t *testing.T) {
+	s, srv := newTestRemoteDisposableSource(t, "mailinator.com\ntrashmail.com\n\n  \n", http.StatusOK)
+	defer srv.Close()
+
+	if err := s.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh returned error: %v", err)
+	}
+	if !s.IsDisposable("mailinator.com") || !s.IsDisposable("trashmail.com") {
+		t.Fatal("Refresh didn't parse the newline-delimited feed")
+	}
+}
+
+func TestRemoteDisposableSourceRefreshFailureKeepsOldList(t *testing.T) {
+	s, srv := newTestRemoteDisposableSource(t, "mailinator.com", http.StatusOK)
+	defer srv.Close()
+
+	if err := s.Refresh(context.Background()); err != nil {
+		t.Fatalf("initial Refresh returned error: %v", err)
+	}
+
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	if err := s.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh returned nil error for a failing fetch")
+	}
+	if !s.IsDisposable("mailinator.com") {
+		t.Fatal("a failed Refresh discarded the previously fetched list")
+	}
+}
+
+func TestRemoteDisposableSourceRefreshEmptyFeedIsAnError(t *testing.T) {
+	s, srv := newTestRemoteDisposableSource(t, "", http.StatusOK)
+	defer srv.Close()
+
+	if err := s.Refresh(context.Background()); err == nil {
+		t.Fatal("Refresh returned nil error for an empty feed")
+	}
+}
@@ -0,0 +1,88 @@
+package verifier
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// defaultDNSCacheTTL bounds how long a domain's DomainInfo is cached
+// before checkDNS re-resolves it.
+const defaultDNSCacheTTL = time.Minute * 10
+
+// DomainInfo holds the DNS signal checkDNS collects for a single domain:
+// its MX records plus SPF/DMARC presence, which is useful for scoring
+// deliverability even without an SMTP probe.
+type DomainInfo struct {
+	Domain      string
+	HasMX       bool
+	MXRecords   []string
+	HasSPF      bool
+	SPFRecord   string
+	HasDMARC    bool
+	DMARCRecord string
+}
+
+// dnsCacheEntry is the value stored in Verifier.dnsCache.
+type dnsCacheEntry struct {
+	info    *DomainInfo
+	expires time.Time
+}
+
+// CheckDomain resolves MX, SPF and DMARC records for domain, independent
+// of any particular address, for callers who just want domain-level
+// deliverability signal without an SMTP probe. Results are cached per
+// domain for defaultDNSCacheTTL.
+func (v *Verifier) CheckDomain(domain string) (*DomainInfo, error) {
+	return v.checkDNS(domain)
+}
+
+// checkDNS resolves domain's MX records (sorted by ascending preference)
+// and its SPF/DMARC TXT records, caching the result per-domain so bulk
+// verification of addresses at the same domain only pays DNS cost once.
+func (v *Verifier) checkDNS(domain string) (*DomainInfo, error) {
+	if cached, ok := v.dnsCache.Load(domain); ok {
+		entry := cached.(dnsCacheEntry)
+		if time.Now().Before(entry.expires) {
+			return entry.info, nil
+		}
+		v.dnsCache.Delete(domain)
+	}
+
+	info := &DomainInfo{Domain: domain}
+
+	mxs, err := net.LookupMX(domain)
+	if err != nil {
+		return nil, fmt.Errorf("failed resolving MX records for %s: %w", domain, err)
+	}
+	sort.Slice(mxs, func(i, j int) bool { return mxs[i].Pref < mxs[j].Pref })
+	for _, mx := range mxs {
+		info.MXRecords = append(info.MXRecords, mx.Host)
+	}
+	info.HasMX = len(info.MXRecords) > 0
+
+	if txts, err := net.LookupTXT(domain); err == nil {
+		for _, txt := range txts {
+			if strings.HasPrefix(txt, "v=spf1") {
+				info.HasSPF = true
+				info.SPFRecord = txt
+				break
+			}
+		}
+	}
+
+	if txts, err := net.LookupTXT("_dmarc." + domain); err == nil {
+		for _, txt := range txts {
+			if strings.HasPrefix(txt, "v=DMARC1") {
+				info.HasDMARC = true
+				info.DMARCRecord = txt
+				break
+			}
+		}
+	}
+
+	v.dnsCache.Store(domain, dnsCacheEntry{info: info, expires: time.Now().Add(v.dnsCacheTTL)})
+	return info, nil
+}
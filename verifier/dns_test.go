@@ -0,0 +1,47 @@
+package verifier
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCachedMXHostUsesDNSCache is a regression test for smtpProbe's
+// APIVerifier dispatch paying for a second, uncached MX lookup even when
+// checkDNS already resolved (and cached) the same domain: it pre-seeds
+// the cache and relies on cachedMXHost never touching the network to
+// return the right answer.
+func TestCachedMXHostUsesDNSCache(t *testing.T) {
+	v := &Verifier{}
+	v.dnsCache.Store("example.com", dnsCacheEntry{
+		info: &DomainInfo{
+			Domain:    "example.com",
+			HasMX:     true,
+			MXRecords: []string{"mx1.example.com", "mx2.example.com"},
+		},
+		expires: time.Now().Add(time.Minute),
+	})
+
+	got, err := v.cachedMXHost("example.com")
+	if err != nil {
+		t.Fatalf("cachedMXHost returned error: %v", err)
+	}
+	if got != "mx1.example.com" {
+		t.Fatalf("cachedMXHost = %q, want the cached preferred host %q", got, "mx1.example.com")
+	}
+}
+
+func TestCachedMXHostNoMXRecords(t *testing.T) {
+	v := &Verifier{}
+	v.dnsCache.Store("example.com", dnsCacheEntry{
+		info:    &DomainInfo{Domain: "example.com", HasMX: false},
+		expires: time.Now().Add(time.Minute),
+	})
+
+	got, err := v.cachedMXHost("example.com")
+	if err != nil {
+		t.Fatalf("cachedMXHost returned error: %v", err)
+	}
+	if got != "" {
+		t.Fatalf("cachedMXHost = %q, want empty string when the domain has no MX records", got)
+	}
+}
@@ -0,0 +1,40 @@
+package verifier
+
+import "strings"
+
+// gmailMXPattern matches the MX hostnames Google hands out for Gmail and
+// G Suite domains, e.g. "alt1.gmail-smtp-in.l.google.com".
+const gmailMXPattern = "*.google.com"
+
+// GmailAPIVerifier verifies addresses hosted on Gmail/G Suite MX servers
+// using the SMTP "recipient address rejected" vs. "OK" distinction Google
+// returns on RCPT TO, which survives even though Google blocks the more
+// intrusive generic SMTP probe sequence.
+type GmailAPIVerifier struct {
+	dial *DialConfig
+}
+
+// NewGmailAPIVerifier returns a new GmailAPIVerifier that will dial
+// Google's SMTP servers using dial.
+func NewGmailAPIVerifier(dial *DialConfig) *GmailAPIVerifier {
+	return &GmailAPIVerifier{dial}
+}
+
+// Supports reports whether mxHostname is one of Google's Gmail MX servers.
+func (g *GmailAPIVerifier) Supports(mxHostname string) bool {
+	return matchesHostPattern(gmailMXPattern, strings.ToLower(mxHostname))
+}
+
+// Verify dials the Gmail MX server directly and relies on the RCPT TO
+// response to distinguish a deliverable mailbox from one Google rejects
+// outright, without attempting the generic CatchAll probe Google blocks.
+func (g *GmailAPIVerifier) Verify(address *Address, mxHostname string) (*Lookup, error) {
+	del, err := NewDeliverablerFromHost(mxHostname, g.dial)
+	if err != nil {
+		return nil, parseSTDErr(err)
+	}
+	defer del.Close()
+
+	l := &Lookup{Address: *address, HostExists: true}
+	return classifyRCPTResult(l, del.IsDeliverable(address.Address, 1))
+}
@@ -0,0 +1,61 @@
+package verifier
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+)
+
+// httpProxyDialer dials through an HTTP(S) proxy using the CONNECT
+// method, for providers/networks where SOCKS5 egress isn't available.
+type httpProxyDialer struct {
+	proxyURL *url.URL
+	forward  *net.Dialer
+}
+
+func newHTTPProxyDialer(proxyURL *url.URL, forward *net.Dialer) *httpProxyDialer {
+	return &httpProxyDialer{proxyURL: proxyURL, forward: forward}
+}
+
+// Dial opens a TCP connection to the proxy (wrapped in TLS for an https://
+// proxy URL) and asks it, via CONNECT, to tunnel to address.
+func (d *httpProxyDialer) Dial(network, address string) (net.Conn, error) {
+	conn, err := d.forward.Dial(network, d.proxyURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed dialing HTTP proxy %s: %w", d.proxyURL.Host, err)
+	}
+	if d.proxyURL.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: d.proxyURL.Hostname()})
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if d.proxyURL.User != nil {
+		if pw, ok := d.proxyURL.User.Password(); ok {
+			req.SetBasicAuth(d.proxyURL.User.Username(), pw)
+		}
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed writing CONNECT request to %s: %w", d.proxyURL.Host, err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed reading CONNECT response from %s: %w", d.proxyURL.Host, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("HTTP proxy %s refused CONNECT to %s: %s", d.proxyURL.Host, address, resp.Status)
+	}
+	return conn, nil
+}
@@ -0,0 +1,41 @@
+package verifier
+
+import "strings"
+
+// outlookMXPattern matches the MX hostnames Microsoft hands out for
+// Outlook.com/Office 365 domains, e.g.
+// "domain-com.mail.protection.outlook.com".
+const outlookMXPattern = "*.outlook.com"
+
+// OutlookAPIVerifier verifies addresses hosted on Outlook/Office 365 MX
+// servers using the SMTP RCPT TO response, since Microsoft blocks the
+// more intrusive generic SMTP probe sequence the same way Gmail does.
+type OutlookAPIVerifier struct {
+	dial *DialConfig
+}
+
+// NewOutlookAPIVerifier returns a new OutlookAPIVerifier that will dial
+// Microsoft's SMTP servers using dial.
+func NewOutlookAPIVerifier(dial *DialConfig) *OutlookAPIVerifier {
+	return &OutlookAPIVerifier{dial}
+}
+
+// Supports reports whether mxHostname is one of Microsoft's Outlook MX
+// servers.
+func (o *OutlookAPIVerifier) Supports(mxHostname string) bool {
+	return matchesHostPattern(outlookMXPattern, strings.ToLower(mxHostname))
+}
+
+// Verify dials the Outlook MX server directly and relies on the RCPT TO
+// response to distinguish a deliverable mailbox from one Microsoft
+// rejects outright.
+func (o *OutlookAPIVerifier) Verify(address *Address, mxHostname string) (*Lookup, error) {
+	del, err := NewDeliverablerFromHost(mxHostname, o.dial)
+	if err != nil {
+		return nil, parseSTDErr(err)
+	}
+	defer del.Close()
+
+	l := &Lookup{Address: *address, HostExists: true}
+	return classifyRCPTResult(l, del.IsDeliverable(address.Address, 1))
+}
@@ -0,0 +1,95 @@
+package verifier
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// slowDisposableSource is a DisposableSource whose IsDisposable blocks
+// for delay before returning, used to exercise verifyAddress's
+// per-check timeout degrade path without touching the network.
+type slowDisposableSource struct {
+	delay      time.Duration
+	disposable bool
+}
+
+func (s *slowDisposableSource) IsDisposable(domain string) bool {
+	time.Sleep(s.delay)
+	return s.disposable
+}
+
+func (s *slowDisposableSource) Refresh(ctx context.Context) error { return nil }
+
+func TestRunWithTimeoutReturnsValue(t *testing.T) {
+	v := &Verifier{}
+	got := v.runWithTimeout(context.Background(), time.Second, func() interface{} { return 42 })
+	if got != 42 {
+		t.Fatalf("runWithTimeout = %v, want 42", got)
+	}
+}
+
+func TestRunWithTimeoutReturnsNilOnTimeout(t *testing.T) {
+	v := &Verifier{}
+	got := v.runWithTimeout(context.Background(), 10*time.Millisecond, func() interface{} {
+		time.Sleep(time.Second)
+		return 42
+	})
+	if got != nil {
+		t.Fatalf("runWithTimeout = %v, want nil on timeout", got)
+	}
+}
+
+// TestVerifyAddressDegradesSlowDisposableCheck verifies that a Disposable
+// check which doesn't finish within its own timeout leaves Disposable at
+// its zero value and doesn't fail the overall Lookup.
+func TestVerifyAddressDegradesSlowDisposableCheck(t *testing.T) {
+	v := &Verifier{disp: &slowDisposableSource{delay: 50 * time.Millisecond, disposable: true}}
+	addr := &Address{Address: "user@example.com", Domain: "example.com"}
+	opts := LookupOptions{CheckDisposable: true, DisposableTimeout: 10 * time.Millisecond}
+
+	l, err := v.verifyAddress(context.Background(), addr, opts)
+	if err != nil {
+		t.Fatalf("verifyAddress returned error: %v", err)
+	}
+	if l.Disposable {
+		t.Fatal("Disposable = true, want false (zero value) after the check timed out")
+	}
+}
+
+// TestVerifyAddressHostExistsFallsBackToHasMX verifies that when
+// CheckSMTP is disabled, HostExists is derived from the DNS check's
+// HasMX instead of staying at its zero value. The DNS cache is
+// pre-seeded so the test never touches the network.
+func TestVerifyAddressHostExistsFallsBackToHasMX(t *testing.T) {
+	v := &Verifier{dnsCacheTTL: time.Minute}
+	v.dnsCache.Store("example.com", dnsCacheEntry{
+		info:    &DomainInfo{Domain: "example.com", HasMX: true, MXRecords: []string{"mx.example.com"}},
+		expires: time.Now().Add(time.Minute),
+	})
+	addr := &Address{Address: "user@example.com", Domain: "example.com"}
+	opts := LookupOptions{CheckDNS: true, DNSTimeout: time.Second}
+
+	l, err := v.verifyAddress(context.Background(), addr, opts)
+	if err != nil {
+		t.Fatalf("verifyAddress returned error: %v", err)
+	}
+	if !l.HostExists {
+		t.Fatal("HostExists = false, want true to fall back to HasMX when CheckSMTP is disabled")
+	}
+}
+
+// TestVerifyAddressFatalNoMXAbortsLookup verifies that a domain with no
+// resolvable MX record fails the whole Lookup rather than just degrading
+// the SMTP fields, since every other sub-check is equally unrecoverable
+// without a host to talk to. ".invalid" is reserved by RFC 2606 to never
+// resolve, so this never actually dials anything.
+func TestVerifyAddressFatalNoMXAbortsLookup(t *testing.T) {
+	v := &Verifier{}
+	addr := &Address{Address: "user@trumail-test.invalid", Domain: "trumail-test.invalid"}
+	opts := LookupOptions{CheckSMTP: true, SMTPTimeout: 3 * time.Second}
+
+	if _, err := v.verifyAddress(context.Background(), addr, opts); err == nil {
+		t.Fatal("verifyAddress returned nil error for a domain with no MX record, want a fatal error")
+	}
+}
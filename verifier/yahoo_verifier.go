@@ -0,0 +1,49 @@
+package verifier
+
+import "strings"
+
+// yahooMXPattern matches the MX hostnames Yahoo hands out for Yahoo Mail
+// and AOL domains, e.g. "mta7.am0.yahoodns.net".
+const yahooMXPattern = "*.yahoodns.net"
+
+// YahooAPIVerifier verifies addresses hosted on Yahoo Mail MX servers
+// using a greeting-and-RCPT sequence tuned to Yahoo's aggressive rate
+// limiting, retrying once on a temporary throttle response instead of
+// surfacing it as a hard failure.
+type YahooAPIVerifier struct {
+	dial *DialConfig
+}
+
+// NewYahooAPIVerifier returns a new YahooAPIVerifier that will dial
+// Yahoo's SMTP servers using dial.
+func NewYahooAPIVerifier(dial *DialConfig) *YahooAPIVerifier {
+	return &YahooAPIVerifier{dial}
+}
+
+// Supports reports whether mxHostname is one of Yahoo's MX servers.
+func (y *YahooAPIVerifier) Supports(mxHostname string) bool {
+	return matchesHostPattern(yahooMXPattern, strings.ToLower(mxHostname))
+}
+
+// Verify dials the Yahoo MX server and performs the RCPT TO probe,
+// retrying once if Yahoo responds with its transient rate-limit error
+// before giving up and surfacing the failure.
+func (y *YahooAPIVerifier) Verify(address *Address, mxHostname string) (*Lookup, error) {
+	del, err := NewDeliverablerFromHost(mxHostname, y.dial)
+	if err != nil {
+		return nil, parseSTDErr(err)
+	}
+	defer del.Close()
+
+	l := &Lookup{Address: *address, HostExists: true}
+	err = del.IsDeliverable(address.Address, 1)
+	if le := parseRCPTErr(err); le != nil && le.Message == ErrTryAgainLater {
+		del2, derr := NewDeliverablerFromHost(mxHostname, y.dial)
+		if derr != nil {
+			return nil, parseSTDErr(derr)
+		}
+		defer del2.Close()
+		err = del2.IsDeliverable(address.Address, 1)
+	}
+	return classifyRCPTResult(l, err)
+}